@@ -24,43 +24,68 @@ type Sentence []string
 
 // Compute calculates the BLEU score for a given candidate, references and ngram weights.
 func Compute(candidate Sentence, references []Sentence, weights []float64) float64 {
-	return computeBleu(candidate, references, weights, false)
+	return ComputeWith(candidate, references, weights, Method0)
 }
 
 // Smooth adds a smoothing factor so that any missing ngrams do not result in a score of 0.
 // This is useful in settings where the bleu score is being calcuated on the individual sentence
 // level. See section 4 of Lin and Och 2004 (http://acl.ldc.upenn.edu/C/C04/C04-1072.pdf)
 func Smooth(candidate Sentence, references []Sentence, weights []float64) float64 {
-	return computeBleu(candidate, references, weights, true)
+	return ComputeWith(candidate, references, weights, Method2)
 }
 
-func computeBleu(candidate Sentence, references []Sentence, weights []float64, smoothing bool) float64 {
-	// convert candidate tokens to lower case
-	for i := range candidate {
-		candidate[i] = strings.ToLower(candidate[i])
+// ComputeCorpus calculates a single corpus-level BLEU score for a list of
+// candidates and their corresponding references, rather than averaging
+// per-sentence scores. Clipped n-gram matches and totals are accumulated
+// across every (candidate, references) pair before the precisions and
+// brevity penalty are computed, which is the standard way BLEU is reported
+// at the corpus level (see NLTK's corpus_bleu). candidates and references
+// must be the same length, with references[i] holding the reference
+// translations for candidates[i].
+func ComputeCorpus(candidates []Sentence, references [][]Sentence, weights []float64) float64 {
+	lowered := make([]Sentence, len(candidates))
+	loweredRefs := make([][]Sentence, len(candidates))
+	for i := range candidates {
+		lowered[i] = lowercased(candidates[i])
+		loweredRefs[i] = lowercasedAll(references[i])
 	}
+	return computeCorpusPrecisions(lowered, loweredRefs, weights)
+}
 
-	// convert reference tokens to lower case
-	for i := range references {
-		for u := range references[i] {
-			references[i][u] = strings.ToLower(references[i][u])
+// computeCorpusPrecisions is ComputeCorpus without the forced lowercasing,
+// so callers that have already decided whether to fold case -- such as
+// Scorer, which has its own Lowercase option -- don't have it redone for
+// them.
+func computeCorpusPrecisions(candidates []Sentence, references [][]Sentence, weights []float64) float64 {
+	numerators := make([]int, len(weights))
+	denominators := make([]int, len(weights))
+	candidateLen := 0
+	refLen := 0
+
+	for i := range candidates {
+		candidate := candidates[i]
+		refs := references[i]
+
+		for n := range weights {
+			numerator, denominator := modifiedPrecisionCounts(candidate, refs, n+1)
+			numerators[n] += numerator
+			denominators[n] += denominator
 		}
-	}
 
-	// calculate BLEU modified precision
-	ps := make([]float64, len(weights))
-	for i := range weights {
-		ps[i] = modifiedPrecision(candidate, references, i+1, smoothing)
+		candidateLen += len(candidate)
+		refLen += closestRefLength(len(candidate), refs)
 	}
 
 	s := 0.0
 	overlap := 0
 	for i := range weights {
-		w := weights[i]
-		pn := ps[i]
+		if denominators[i] == 0 {
+			continue
+		}
+		pn := float64(numerators[i]) / float64(denominators[i])
 		if pn > 0.0 {
 			overlap++
-			s += w * math.Log(pn)
+			s += weights[i] * math.Log(pn)
 		}
 	}
 
@@ -70,7 +95,7 @@ func computeBleu(candidate Sentence, references []Sentence, weights []float64, s
 		return 0
 	}
 
-	bp := brevityPenalty(candidate, references)
+	bp := lengthPenalty(candidateLen, refLen)
 	return bp * math.Exp(s)
 }
 
@@ -107,6 +132,35 @@ func countNgrams(ngrams []phrase) map[string]int {
 	return counts
 }
 
+// lowercased returns a copy of s with every token lowercased, leaving s
+// itself untouched. Compute and friends accept caller-owned slices, so they
+// must not mutate them in place -- a caller who reuses a reference list
+// across several scoring calls would otherwise see it silently lowercased
+// out from under them.
+func lowercased(s Sentence) Sentence {
+	out := make(Sentence, len(s))
+	for i := range s {
+		out[i] = strings.ToLower(s[i])
+	}
+	return out
+}
+
+func lowercasedAll(sentences []Sentence) []Sentence {
+	out := make([]Sentence, len(sentences))
+	for i := range sentences {
+		out[i] = lowercased(sentences[i])
+	}
+	return out
+}
+
+// NGramCounts returns the number of times each n-gram of the given order
+// appears in s, keyed by its JSON-encoded token slice. It is exported so
+// that sibling packages, such as rouge, can reuse BLEU's n-gram counting
+// instead of reimplementing it.
+func NGramCounts(s Sentence, n int) map[string]int {
+	return countNgrams(getNgrams(s, n))
+}
+
 func sum(m map[string]int) int {
 	s := 0
 	for _, v := range m {
@@ -135,15 +189,34 @@ func abs(a int) int {
 // has very high precision. So in the modified n-gram precision, a reference
 // word will be considered exhausted after a matching candidate word is identified.
 func modifiedPrecision(candidate Sentence, references []Sentence, n int, smoothing bool) float64 {
+	numerator, denominator := modifiedPrecisionCounts(candidate, references, n)
+	if denominator == 0 {
+		return 0.0
+	}
+
+	// we add smoothing to these so that we never return 0.0
+	smoothingFactor := 0.0
+	if smoothing {
+		smoothingFactor = 1.0
+	}
+	return (float64(numerator) + smoothingFactor) / (float64(denominator) + smoothingFactor)
+}
+
+// modifiedPrecisionCounts returns the raw clipped-match numerator and total
+// n-gram denominator behind modifiedPrecision, before any smoothing is
+// applied. Callers that need to aggregate precision across a corpus (rather
+// than a single sentence) sum these counts across candidates before dividing,
+// instead of recomputing n-grams per sentence.
+func modifiedPrecisionCounts(candidate Sentence, references []Sentence, n int) (numerator, denominator int) {
 	ngrams := getNgrams(candidate, n)
 	if len(ngrams) == 0 {
-		return 0.0
+		return 0, 0
 	}
 
 	counts := countNgrams(ngrams)
 
 	if len(counts) == 0 {
-		return 0.0
+		return 0, 0
 	}
 
 	maxCounts := map[string]int{}
@@ -163,21 +236,13 @@ func modifiedPrecision(candidate Sentence, references []Sentence, n int, smoothi
 		clippedCounts[ngram] = min(count, maxCounts[ngram])
 	}
 
-	// we add smoothing to these so that we never return 0.0
-	smoothingFactor := 0.0
-	if smoothing {
-		smoothingFactor = 1.0
-	}
-	return (float64(sum(clippedCounts)) + smoothingFactor) / (float64(sum(counts)) + smoothingFactor)
+	return sum(clippedCounts), sum(counts)
 }
 
-// brevityPenalty applies a penalty to translation candidates that are longer
-// than the reference translations.
-// As the modified n-gram precision still has the problem from the short
-// length sentence, brevity penalty is used to modify the overall BLEU
-// score according to length.
-func brevityPenalty(candidate Sentence, references []Sentence) float64 {
-	c := len(candidate)
+// closestRefLength returns the length of the reference translation closest
+// to the candidate length c, breaking ties toward the first reference, as
+// used by both the sentence- and corpus-level brevity penalty.
+func closestRefLength(c int, references []Sentence) int {
 	refLens := []int{}
 	for i := range references {
 		refLens = append(refLens, len(references[i]))
@@ -189,7 +254,24 @@ func brevityPenalty(candidate Sentence, references []Sentence) float64 {
 			minDiff = abs(refLens[i] - c)
 		}
 	}
-	r := refLens[minDiffInd]
+	return refLens[minDiffInd]
+}
+
+// brevityPenalty applies a penalty to translation candidates that are shorter
+// than the reference translations.
+// As the modified n-gram precision still has the problem from the short
+// length sentence, brevity penalty is used to modify the overall BLEU
+// score according to length.
+func brevityPenalty(candidate Sentence, references []Sentence) float64 {
+	c := len(candidate)
+	r := closestRefLength(c, references)
+	return lengthPenalty(c, r)
+}
+
+// lengthPenalty computes the brevity penalty from a candidate length c and
+// an effective reference length r, so the corpus-level aggregation can reuse
+// it against summed lengths instead of a single sentence pair.
+func lengthPenalty(c, r int) float64 {
 	if c > r {
 		return 1
 	}
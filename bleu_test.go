@@ -187,6 +187,24 @@ func TestSmoothBLEUScore(t *testing.T) {
 	}
 }
 
+func TestComputeCorpus(t *testing.T) {
+	candidates := []Sentence{
+		split("It is a guide to action which ensures that the military always obeys the commands of the party"),
+		split("ham and egg"),
+	}
+	referencesList := [][]Sentence{
+		references2,
+		{split("Ham and Eggs")},
+	}
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	score := ComputeCorpus(candidates, referencesList, weights)
+	want := 0.4831
+	if math.Abs(score-want) > 0.01 {
+		t.Errorf("ComputeCorpus got %v, want %v", score, want)
+	}
+}
+
 func ExampleCompute() {
 	references := []Sentence{
 		strings.Split("the cat is on the mat", " "),
@@ -0,0 +1,186 @@
+package bleu
+
+import "math/big"
+
+// exactWorkingGuardBits is the number of extra bits of precision series
+// summation is carried out at, beyond what the caller asked for, so that
+// rounding in intermediate terms doesn't erode the requested precision of
+// the final result.
+const exactWorkingGuardBits = 64
+
+// defaultExactPrecision is the big.Float precision, in bits, ComputeExact
+// uses by default.
+const defaultExactPrecision = 128
+
+// ComputeExact calculates the BLEU score like Compute, but does every step
+// after forming the per-order precisions -- the weighted log-sum and its
+// exponent, and the brevity penalty's exponent -- with big.Float arithmetic
+// and a from-scratch log/exp built only out of big.Float's arbitrary
+// precision add/multiply/divide, rather than the standard library's
+// math.Log/math.Exp.
+//
+// This buys a narrower but real guarantee than "bit-identical to NLTK":
+// math.Log and math.Exp are ultimately backed by a libm (or, on some
+// platforms, Go's own pure-Go approximations), and either can change
+// between Go versions, operating systems or CPU architectures without
+// changing this package's source at all. big.Float's Add/Mul/Quo are
+// specified exactly by the Go spec regardless of platform, so bigLn/bigExp
+// below compute the same result everywhere any correct Go implementation
+// runs, which math.Log/math.Exp do not promise. It does not, and cannot
+// without reimplementing Python's fractions/math stack bit-for-bit, make
+// this package's output identical to NLTK's -- that would additionally
+// require matching Python's own transcendental function implementation,
+// which is out of this package's control.
+func ComputeExact(candidate Sentence, references []Sentence, weights []float64) float64 {
+	return ComputeExactPrec(candidate, references, weights, defaultExactPrecision)
+}
+
+// ComputeExactPrec is ComputeExact with the big.Float precision, in bits,
+// used throughout made explicit.
+func ComputeExactPrec(candidate Sentence, references []Sentence, weights []float64, prec uint) float64 {
+	candidate = lowercased(candidate)
+	references = lowercasedAll(references)
+
+	s := newBigFloat(prec, 0)
+	overlap := 0
+	for i := range weights {
+		numerator, denominator := modifiedPrecisionCounts(candidate, references, i+1)
+		if denominator == 0 || numerator == 0 {
+			continue
+		}
+		overlap++
+
+		pn := new(big.Float).SetPrec(prec).SetRat(big.NewRat(int64(numerator), int64(denominator)))
+		w := new(big.Float).SetPrec(prec).SetFloat64(weights[i])
+		term := new(big.Float).SetPrec(prec).Mul(w, bigLn(pn, prec))
+		s.Add(s, term)
+	}
+
+	// if none of the ngrams have any overlap with the reference translations,
+	// return 0. See https://github.com/nltk/nltk/issues/1268 for discussion.
+	if overlap == 0 {
+		return 0
+	}
+
+	bp := exactBrevityPenalty(candidate, references, prec)
+	score := new(big.Float).SetPrec(prec).Mul(bp, bigExp(s, prec))
+	result, _ := score.Float64()
+	return result
+}
+
+// exactBrevityPenalty computes the same penalty as brevityPenalty, but
+// evaluates both its exponent, 1 - r/c, and exp of that exponent with
+// big.Float, converting to float64 only implicitly through the big.Float
+// result ComputeExactPrec later multiplies it into.
+func exactBrevityPenalty(candidate Sentence, references []Sentence, prec uint) *big.Float {
+	c := len(candidate)
+	r := closestRefLength(c, references)
+	if c > r {
+		return newBigFloat(prec, 1)
+	}
+
+	ratio := new(big.Float).SetPrec(prec).SetRat(big.NewRat(int64(r), int64(c)))
+	exponent := new(big.Float).SetPrec(prec).Sub(newBigFloat(prec, 1), ratio)
+	return bigExp(exponent, prec)
+}
+
+func newBigFloat(prec uint, v int64) *big.Float {
+	return new(big.Float).SetPrec(prec).SetInt64(v)
+}
+
+// negligible reports whether x is small enough, relative to prec, that
+// adding it to a running series sum would no longer change the result --
+// i.e. its binary exponent is below -prec.
+func negligible(x *big.Float, prec uint) bool {
+	if x.Sign() == 0 {
+		return true
+	}
+	mant := new(big.Float)
+	exp := x.MantExp(mant)
+	return exp < -int(prec)
+}
+
+// bigAtanh computes atanh(y) = y + y^3/3 + y^5/5 + ... for |y| < 1, used as
+// the building block for both bigLn2 and bigLn. Series summation is done at
+// prec+exactWorkingGuardBits bits and rounded down to prec at the end.
+func bigAtanh(y *big.Float, prec uint) *big.Float {
+	working := prec + exactWorkingGuardBits
+
+	yw := new(big.Float).SetPrec(working).Set(y)
+	y2 := new(big.Float).SetPrec(working).Mul(yw, yw)
+	term := new(big.Float).SetPrec(working).Set(yw)
+	sum := new(big.Float).SetPrec(working).Set(yw)
+
+	for n := int64(3); ; n += 2 {
+		term.Mul(term, y2)
+		delta := new(big.Float).SetPrec(working).Quo(term, new(big.Float).SetPrec(working).SetInt64(n))
+		sum.Add(sum, delta)
+		if negligible(delta, working) {
+			break
+		}
+	}
+
+	return new(big.Float).SetPrec(prec).Set(sum)
+}
+
+// bigLn2 computes ln(2) = 2*atanh(1/3), used by bigLn to undo the power-of-2
+// scaling big.Float.MantExp reduces its argument by.
+func bigLn2(prec uint) *big.Float {
+	working := prec + exactWorkingGuardBits
+	third := new(big.Float).SetPrec(working).Quo(newBigFloat(working, 1), newBigFloat(working, 3))
+	return new(big.Float).SetPrec(prec).Mul(bigAtanh(third, working), newBigFloat(working, 2))
+}
+
+// bigLn computes the natural log of x > 0 using only big.Float arithmetic:
+// x is range-reduced to mant*2^exp with mant in [0.5, 1) via MantExp, then
+// ln(x) = ln(mant) + exp*ln(2), where ln(mant) = 2*atanh((mant-1)/(mant+1))
+// converges quickly because |mant-1|/|mant+1| <= 1/3 throughout that range.
+func bigLn(x *big.Float, prec uint) *big.Float {
+	working := prec + exactWorkingGuardBits
+
+	mant := new(big.Float).SetPrec(working)
+	exp := x.MantExp(mant)
+
+	num := new(big.Float).SetPrec(working).Sub(mant, newBigFloat(working, 1))
+	den := new(big.Float).SetPrec(working).Add(mant, newBigFloat(working, 1))
+	y := new(big.Float).SetPrec(working).Quo(num, den)
+
+	lnMant := new(big.Float).SetPrec(working).Mul(bigAtanh(y, working), newBigFloat(working, 2))
+	expTerm := new(big.Float).SetPrec(working).Mul(newBigFloat(working, int64(exp)), bigLn2(working))
+
+	return new(big.Float).SetPrec(prec).Add(lnMant, expTerm)
+}
+
+// bigExp computes e^x using only big.Float arithmetic: x is halved by
+// repeated squaring-friendly range reduction until |x| <= 0.5, where the
+// Taylor series sum x^n/n! converges quickly and without the cancellation
+// that makes it unreliable for large |x|, then the result is squared back
+// the same number of times.
+func bigExp(x *big.Float, prec uint) *big.Float {
+	working := prec + exactWorkingGuardBits
+
+	reduced := new(big.Float).SetPrec(working).Set(x)
+	half := new(big.Float).SetPrec(working).SetFloat64(0.5)
+	k := 0
+	for new(big.Float).SetPrec(working).Abs(reduced).Cmp(half) > 0 {
+		reduced.Quo(reduced, newBigFloat(working, 2))
+		k++
+	}
+
+	term := newBigFloat(working, 1)
+	sum := newBigFloat(working, 1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, reduced)
+		term.Quo(term, new(big.Float).SetPrec(working).SetInt64(n))
+		sum.Add(sum, term)
+		if negligible(term, working) {
+			break
+		}
+	}
+
+	for i := 0; i < k; i++ {
+		sum.Mul(sum, sum)
+	}
+
+	return new(big.Float).SetPrec(prec).Set(sum)
+}
@@ -0,0 +1,116 @@
+package bleu
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func bigToFloat64(t *testing.T, x *big.Float) float64 {
+	t.Helper()
+	f, _ := x.Float64()
+	return f
+}
+
+func TestBigLnKnownValues(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{"ln(1)", 1, 0},
+		{"ln(e)", math.E, 1},
+		{"ln(2)", 2, math.Ln2},
+		{"ln(10)", 10, math.Log(10)},
+		{"ln(0.25)", 0.25, math.Log(0.25)},
+	}
+	for _, tt := range tests {
+		x := new(big.Float).SetPrec(128).SetFloat64(tt.x)
+		got := bigToFloat64(t, bigLn(x, 128))
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("bigLn(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBigExpKnownValues(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{"exp(0)", 0, 1},
+		{"exp(1)", 1, math.E},
+		{"exp(-1)", -1, 1 / math.E},
+		{"exp(5)", 5, math.Exp(5)},
+		{"exp(-5)", -5, math.Exp(-5)},
+	}
+	for _, tt := range tests {
+		x := new(big.Float).SetPrec(128).SetFloat64(tt.x)
+		got := bigToFloat64(t, bigExp(x, 128))
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("bigExp(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBigLnBigExpAreInverses(t *testing.T) {
+	for _, v := range []float64{0.1, 0.5, 1, 2, 7.3, 42} {
+		x := new(big.Float).SetPrec(128).SetFloat64(v)
+		roundTripped := bigToFloat64(t, bigExp(bigLn(x, 128), 128))
+		if math.Abs(roundTripped-v) > 1e-12 {
+			t.Errorf("bigExp(bigLn(%v)) = %v, want %v", v, roundTripped, v)
+		}
+	}
+}
+
+func TestComputeExactMatchesCompute(t *testing.T) {
+	candidate := split("the cat sat on the mat")
+	references := []Sentence{split("the cat sat on the mat")}
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	got := ComputeExact(candidate, references, weights)
+	want := Compute(candidate, references, weights)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("ComputeExact() = %v, want %v (Compute's result)", got, want)
+	}
+}
+
+func TestComputeExactNoOverlapReturnsZero(t *testing.T) {
+	candidate := split("xyz abc")
+	references := []Sentence{split("completely different words")}
+	weights := []float64{0.5, 0.5}
+
+	if score := ComputeExact(candidate, references, weights); score != 0 {
+		t.Errorf("ComputeExact() = %v, want 0", score)
+	}
+}
+
+func TestComputeExactBrevityPenalty(t *testing.T) {
+	// A short candidate that fully overlaps a longer reference must still be
+	// penalized the same way Compute penalizes it.
+	candidate := split("the cat")
+	references := []Sentence{split("the cat sat on the mat")}
+	weights := []float64{1}
+
+	got := ComputeExact(candidate, references, weights)
+	want := Compute(candidate, references, weights)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("ComputeExact() = %v, want %v (Compute's result)", got, want)
+	}
+}
+
+func TestComputeExactPrecStable(t *testing.T) {
+	// Raising the working precision must not change the float64 result by
+	// more than 1e-9, since both should converge to the same mathematical
+	// value.
+	candidate := split("the cat sat on the mat")
+	references := []Sentence{split("a cat sat on a mat")}
+	weights := []float64{0.5, 0.5}
+
+	low := ComputeExactPrec(candidate, references, weights, 64)
+	high := ComputeExactPrec(candidate, references, weights, 256)
+	if math.Abs(low-high) > 1e-9 {
+		t.Errorf("ComputeExactPrec(64) = %v, ComputeExactPrec(256) = %v, want them to agree", low, high)
+	}
+}
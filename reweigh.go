@@ -0,0 +1,39 @@
+package bleu
+
+import "log"
+
+// ComputeAutoReweigh calculates the BLEU score like Compute, except that if
+// the candidate has fewer tokens than len(weights), the highest n-gram
+// orders can never match. Compute leaves those orders out of the geometric
+// mean, so the weights it does use sum to less than 1. Here the weights are
+// instead truncated to the candidate's length and renormalized to sum to 1
+// before scoring, mirroring
+// the auto_reweigh option in NLTK's sentence_bleu. This is most useful when
+// scoring short candidates such as headlines or chat replies.
+func ComputeAutoReweigh(candidate Sentence, references []Sentence, weights []float64) float64 {
+	return Compute(candidate, references, reweighForLength(candidate, weights))
+}
+
+// reweighForLength truncates weights to len(candidate) orders and
+// renormalizes them to sum to 1 when the candidate is too short to match
+// every requested n-gram order. It logs the adjustment so callers can tell
+// their requested weights were not used as given.
+func reweighForLength(candidate Sentence, weights []float64) []float64 {
+	if len(candidate) >= len(weights) {
+		return weights
+	}
+
+	truncated := append([]float64{}, weights[:len(candidate)]...)
+	total := 0.0
+	for _, w := range truncated {
+		total += w
+	}
+	if total != 0 {
+		for i := range truncated {
+			truncated[i] /= total
+		}
+	}
+
+	log.Printf("bleu: candidate has %d token(s), fewer than the %d requested n-gram orders; reweighed to %v", len(candidate), len(weights), truncated)
+	return truncated
+}
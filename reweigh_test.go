@@ -0,0 +1,35 @@
+package bleu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeAutoReweigh(t *testing.T) {
+	candidate := split("the cat sat")
+	references := []Sentence{split("the cat mat")}
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	score := ComputeAutoReweigh(candidate, references, weights)
+	want := 0.6934
+	if math.Abs(score-want) > 0.001 {
+		t.Errorf("ComputeAutoReweigh = %v, want %v", score, want)
+	}
+}
+
+func TestReweighForLength(t *testing.T) {
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	got := reweighForLength(split("the cat"), weights)
+	want := []float64{0.5, 0.5}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 0.0001 {
+			t.Errorf("reweighForLength(%v) = %v, want %v", weights, got, want)
+		}
+	}
+
+	got = reweighForLength(split("the cat is on the mat"), weights)
+	if len(got) != len(weights) {
+		t.Errorf("reweighForLength should not truncate a candidate at least as long as weights, got %v", got)
+	}
+}
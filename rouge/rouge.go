@@ -0,0 +1,130 @@
+// Package rouge implements the ROUGE family of metrics, which is used
+// alongside BLEU to evaluate the quality of automatic summarization and
+// text generation. [1]
+//
+// [1] Lin, Chin-Yew. "ROUGE: A package for automatic evaluation of
+//     summaries." Text summarization branches out. 2004.
+package rouge
+
+import "github.com/waygo/bleu"
+
+// Sentence represents a series of tokens, reusing the type BLEU scores are
+// computed over so candidates and references can be shared between the two
+// packages.
+type Sentence = bleu.Sentence
+
+// RougeN calculates the ROUGE-N precision, recall and F1 score between a
+// candidate and a single reference. Unlike BLEU's modified precision, which
+// is candidate-oriented, ROUGE-N is recall-oriented: the denominator for
+// recall is the number of n-grams in the reference rather than the
+// candidate. Precision and recall are both derived from the same clipped
+// overlap count, matching BLEU's modified precision.
+func RougeN(candidate, reference Sentence, n int) (precision, recall, f1 float64) {
+	candidateCounts := bleu.NGramCounts(candidate, n)
+	referenceCounts := bleu.NGramCounts(reference, n)
+	return precisionRecallF1(overlapCount(candidateCounts, referenceCounts), ngramTotal(candidateCounts), ngramTotal(referenceCounts))
+}
+
+// RougeNCorpus calculates a single corpus-level ROUGE-N score for a list of
+// candidates and their corresponding references, accumulating the overlap
+// and totals across every pair before computing precision, recall and F1,
+// the same way ComputeCorpus aggregates BLEU across a corpus. candidates and
+// references must be the same length.
+func RougeNCorpus(candidates, references []Sentence, n int) (precision, recall, f1 float64) {
+	overlap, candidateTotal, referenceTotal := 0, 0, 0
+	for i := range candidates {
+		candidateCounts := bleu.NGramCounts(candidates[i], n)
+		referenceCounts := bleu.NGramCounts(references[i], n)
+		overlap += overlapCount(candidateCounts, referenceCounts)
+		candidateTotal += ngramTotal(candidateCounts)
+		referenceTotal += ngramTotal(referenceCounts)
+	}
+	return precisionRecallF1(overlap, candidateTotal, referenceTotal)
+}
+
+// RougeL calculates the ROUGE-L precision, recall and F1 score between a
+// candidate and a single reference, based on the length of their longest
+// common subsequence (LCS).
+func RougeL(candidate, reference Sentence) (precision, recall, f1 float64) {
+	lcs := lcsLength(candidate, reference)
+	return precisionRecallF1(lcs, len(candidate), len(reference))
+}
+
+// RougeLCorpus calculates a single corpus-level ROUGE-L score for a list of
+// candidates and their corresponding references, summing LCS lengths and
+// token counts across the corpus before computing precision, recall and F1.
+// candidates and references must be the same length.
+func RougeLCorpus(candidates, references []Sentence) (precision, recall, f1 float64) {
+	lcsTotal, candidateTotal, referenceTotal := 0, 0, 0
+	for i := range candidates {
+		lcsTotal += lcsLength(candidates[i], references[i])
+		candidateTotal += len(candidates[i])
+		referenceTotal += len(references[i])
+	}
+	return precisionRecallF1(lcsTotal, candidateTotal, referenceTotal)
+}
+
+// overlapCount returns the number of n-grams a and b have in common, each
+// counted at most as many times as it appears in the less frequent of the
+// two, the same clipping BLEU's modified precision applies.
+func overlapCount(a, b map[string]int) int {
+	overlap := 0
+	for ngram, countA := range a {
+		if countB, ok := b[ngram]; ok {
+			overlap += min(countA, countB)
+		}
+	}
+	return overlap
+}
+
+func ngramTotal(counts map[string]int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// precisionRecallF1 turns a raw match count and the candidate/reference
+// totals it was measured against into precision, recall and their harmonic
+// mean, used by both RougeN and RougeL.
+func precisionRecallF1(matched, candidateTotal, referenceTotal int) (precision, recall, f1 float64) {
+	if candidateTotal > 0 {
+		precision = float64(matched) / float64(candidateTotal)
+	}
+	if referenceTotal > 0 {
+		recall = float64(matched) / float64(referenceTotal)
+	}
+	if precision+recall > 0 {
+		f1 = 2 * precision * recall / (precision + recall)
+	}
+	return precision, recall, f1
+}
+
+// lcsLength returns the length of the longest common subsequence between a
+// and b, computed with the standard O(len(a)*len(b)) dynamic program.
+func lcsLength(a, b Sentence) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else if dp[i-1][j] >= dp[i][j-1] {
+				dp[i][j] = dp[i-1][j]
+			} else {
+				dp[i][j] = dp[i][j-1]
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
@@ -0,0 +1,99 @@
+package rouge
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func split(s string) Sentence {
+	return strings.Split(s, " ")
+}
+
+func TestRougeN(t *testing.T) {
+	var cases = []struct {
+		candidate Sentence
+		reference Sentence
+		n         int
+		wantP     float64
+		wantR     float64
+		wantF1    float64
+	}{
+		{
+			candidate: split("the cat sat on the mat"),
+			reference: split("the cat sat on the mat"),
+			n:         1,
+			wantP:     1.0,
+			wantR:     1.0,
+			wantF1:    1.0,
+		},
+		{
+			candidate: split("the cat sat on the mat"),
+			reference: split("the cat was on the mat"),
+			n:         1,
+			wantP:     0.8333,
+			wantR:     0.8333,
+			wantF1:    0.8333,
+		},
+		{
+			candidate: split("the cat sat on the mat"),
+			reference: split("the cat was on the mat"),
+			n:         2,
+			wantP:     0.6,
+			wantR:     0.6,
+			wantF1:    0.6,
+		},
+	}
+
+	for _, tt := range cases {
+		p, r, f1 := RougeN(tt.candidate, tt.reference, tt.n)
+		if math.Abs(p-tt.wantP) > 0.0001 || math.Abs(r-tt.wantR) > 0.0001 || math.Abs(f1-tt.wantF1) > 0.0001 {
+			t.Errorf("RougeN(%q, %q, %d) = (%v, %v, %v), want (%v, %v, %v)", tt.candidate, tt.reference, tt.n, p, r, f1, tt.wantP, tt.wantR, tt.wantF1)
+		}
+	}
+}
+
+func TestRougeL(t *testing.T) {
+	p, r, f1 := RougeL(split("police killed the gunman"), split("police kill the gunman"))
+	wantP, wantR, wantF1 := 0.75, 0.75, 0.75
+	if math.Abs(p-wantP) > 0.0001 || math.Abs(r-wantR) > 0.0001 || math.Abs(f1-wantF1) > 0.0001 {
+		t.Errorf("RougeL = (%v, %v, %v), want (%v, %v, %v)", p, r, f1, wantP, wantR, wantF1)
+	}
+}
+
+func TestRougeNCorpus(t *testing.T) {
+	candidates := []Sentence{
+		split("the cat sat on the mat"),
+		split("a quick brown fox"),
+	}
+	references := []Sentence{
+		split("the cat was on the mat"),
+		split("a quick brown fox jumps"),
+	}
+
+	p, r, f1 := RougeNCorpus(candidates, references, 1)
+	wantP, wantR := 0.9, 0.8182
+	if math.Abs(p-wantP) > 0.0001 || math.Abs(r-wantR) > 0.0001 {
+		t.Errorf("RougeNCorpus precision/recall = (%v, %v), want (%v, %v)", p, r, wantP, wantR)
+	}
+	if f1 <= 0 || f1 >= 1 {
+		t.Errorf("RougeNCorpus f1 = %v, want a value in (0, 1)", f1)
+	}
+}
+
+func TestRougeLCorpus(t *testing.T) {
+	candidates := []Sentence{
+		split("police killed the gunman"),
+		split("the cat sat on the mat"),
+	}
+	references := []Sentence{
+		split("police kill the gunman"),
+		split("the cat was on the mat"),
+	}
+
+	p, r, _ := RougeLCorpus(candidates, references)
+	wantP, wantR := 0.8, 0.8
+	if math.Abs(p-wantP) > 0.0001 || math.Abs(r-wantR) > 0.0001 {
+		t.Errorf("RougeLCorpus precision/recall = (%v, %v), want (%v, %v)", p, r, wantP, wantR)
+	}
+}
@@ -0,0 +1,55 @@
+package bleu
+
+// Scorer bundles the options needed to score raw text end-to-end: how to
+// tokenize it, which smoothing method to apply, the n-gram weights, and
+// whether to lowercase before scoring. Compute, Smooth and ComputeWith all
+// operate on pre-tokenized Sentence values and leave these choices to the
+// caller; Scorer is for callers who would otherwise have to make them by
+// hand for every call.
+type Scorer struct {
+	Tokenizer Tokenizer
+	Method    SmoothingMethod
+	Weights   []float64
+	Lowercase bool
+}
+
+func (s *Scorer) tokenize(text string) Sentence {
+	tokens := s.Tokenizer.Tokenize(text)
+	if s.Lowercase {
+		tokens = lowercased(tokens)
+	}
+	return tokens
+}
+
+// Sentence tokenizes candidate and references with s.Tokenizer and scores
+// them with s.Method and s.Weights.
+func (s *Scorer) Sentence(candidate string, references []string) float64 {
+	candidateTokens := s.tokenize(candidate)
+	referenceTokens := make([]Sentence, len(references))
+	for i, reference := range references {
+		referenceTokens[i] = s.tokenize(reference)
+	}
+	return computeWithPrecisions(candidateTokens, referenceTokens, s.Weights, s.Method)
+}
+
+// Corpus tokenizes every candidate and its corresponding references with
+// s.Tokenizer and computes a single corpus-level BLEU score with
+// s.Weights. candidates and references must be the same length, with
+// references[i] holding the reference translations for candidates[i].
+// Corpus always aggregates raw n-gram counts across the corpus without
+// smoothing, since that is how BLEU is conventionally reported at the
+// corpus level and the aggregation itself already avoids the zero-count
+// problem s.Method exists to paper over at the sentence level; s.Method is
+// only consulted by Sentence.
+func (s *Scorer) Corpus(candidates []string, references [][]string) float64 {
+	candidateTokens := make([]Sentence, len(candidates))
+	referenceTokens := make([][]Sentence, len(candidates))
+	for i, candidate := range candidates {
+		candidateTokens[i] = s.tokenize(candidate)
+		referenceTokens[i] = make([]Sentence, len(references[i]))
+		for j, reference := range references[i] {
+			referenceTokens[i][j] = s.tokenize(reference)
+		}
+	}
+	return computeCorpusPrecisions(candidateTokens, referenceTokens, s.Weights)
+}
@@ -0,0 +1,96 @@
+package bleu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScorerSentence(t *testing.T) {
+	scorer := &Scorer{
+		Tokenizer: WhitespaceTokenizer{},
+		Method:    Method0,
+		Weights:   []float64{0.5, 0.5},
+		Lowercase: true,
+	}
+
+	got := scorer.Sentence("Cat Mat", []string{"cat on the mat"})
+	want := Compute(split("cat mat"), []Sentence{split("cat on the mat")}, []float64{0.5, 0.5})
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("Scorer.Sentence() = %v, want %v", got, want)
+	}
+}
+
+func TestScorerSentenceDoesNotMutateReferences(t *testing.T) {
+	scorer := &Scorer{
+		Tokenizer: WhitespaceTokenizer{},
+		Method:    Method0,
+		Weights:   []float64{0.5, 0.5},
+		Lowercase: true,
+	}
+
+	references := []string{"CAT ON THE MAT"}
+	scorer.Sentence("cat mat", references)
+	if references[0] != "CAT ON THE MAT" {
+		t.Errorf("references mutated unexpectedly: %q", references)
+	}
+}
+
+func TestScorerSentenceLowercaseFalseIsCaseSensitive(t *testing.T) {
+	scorer := &Scorer{
+		Tokenizer: WhitespaceTokenizer{},
+		Method:    Method0,
+		Weights:   []float64{1},
+		Lowercase: false,
+	}
+
+	if score := scorer.Sentence("CAT", []string{"cat"}); score != 0 {
+		t.Errorf(`Scorer{Lowercase: false}.Sentence("CAT", ["cat"]) = %v, want 0 (case must not be folded)`, score)
+	}
+	if score := scorer.Sentence("CAT", []string{"CAT"}); score != 1 {
+		t.Errorf(`Scorer{Lowercase: false}.Sentence("CAT", ["CAT"]) = %v, want 1`, score)
+	}
+}
+
+func TestScorerCorpusLowercaseFalseIsCaseSensitive(t *testing.T) {
+	scorer := &Scorer{
+		Tokenizer: WhitespaceTokenizer{},
+		Weights:   []float64{1},
+		Lowercase: false,
+	}
+
+	candidates := []string{"CAT", "CAT"}
+	references := [][]string{{"cat"}, {"CAT"}}
+
+	got := scorer.Corpus(candidates, references)
+	want := 0.5 // only the second pair matches when case is preserved
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("Scorer{Lowercase: false}.Corpus() = %v, want %v", got, want)
+	}
+}
+
+func TestScorerCorpus(t *testing.T) {
+	scorer := &Scorer{
+		Tokenizer: WhitespaceTokenizer{},
+		Weights:   []float64{0.25, 0.25, 0.25, 0.25},
+		Lowercase: true,
+	}
+
+	candidates := []string{
+		"It is a guide to action which ensures that the military always obeys the commands of the party",
+		"ham and egg",
+	}
+	references := [][]string{
+		{
+			"It is a guide to action that ensures that the military will forever heed Party commands.",
+			"It is the guiding principle which guarantees the military forces always being under the command of the Party.",
+			"It is the practical guide for the army always to heed the directions of the party",
+		},
+		{"Ham and Eggs"},
+	}
+
+	got := scorer.Corpus(candidates, references)
+	want := 0.4831
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("Scorer.Corpus() = %v, want %v", got, want)
+	}
+}
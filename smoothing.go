@@ -0,0 +1,226 @@
+package bleu
+
+import "math"
+
+// SmoothingMethod adjusts the per-order modified precisions of a BLEU score
+// before they enter the geometric mean, so that an n-gram order with no
+// matches can contribute a nonzero precision instead of being left out of
+// the mean. The methods implemented
+// here are methods 0 through 7 from Chen & Cherry, "A Systematic Comparison
+// of Smoothing Techniques for Sentence-Level BLEU", WMT 2014.
+//
+// SmoothingMethod is implemented only by the Method0 .. Method7 values in
+// this package; there is no supported way to plug in a custom method.
+type SmoothingMethod interface {
+	smooth(candidate Sentence, references []Sentence, numerators, denominators []int) []float64
+}
+
+type smoothingFunc func(candidate Sentence, references []Sentence, numerators, denominators []int) []float64
+
+func (f smoothingFunc) smooth(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	return f(candidate, references, numerators, denominators)
+}
+
+// epsilon and alpha are the smoothing constants used by methods 1 and 6,
+// matching the defaults Chen & Cherry use in their reference implementation.
+const (
+	smoothingEpsilon = 0.1
+	smoothingAlpha   = 5.0
+)
+
+var (
+	// Method0 applies no smoothing: an order with no matching n-grams has a
+	// precision of 0 and is left out of the geometric mean; the score is 0
+	// only when every order has a precision of 0. This is the behavior of
+	// the original Compute function.
+	Method0 SmoothingMethod = smoothingFunc(method0)
+
+	// Method1 adds a small epsilon to the numerator of any order with zero
+	// matches, so its precision becomes epsilon/denominator, leaving all
+	// other orders untouched.
+	Method1 SmoothingMethod = smoothingFunc(method1)
+
+	// Method2 adds 1 to both the numerator and denominator of every order's
+	// precision, following Lin & Och 2004. This is the behavior of the
+	// original Smooth function.
+	Method2 SmoothingMethod = smoothingFunc(method2)
+
+	// Method3 is NIST's geometric sequence smoothing: the k-th zero
+	// precision encountered (in increasing n-gram order) is replaced by
+	// 1/(2^k * denominator).
+	Method3 SmoothingMethod = smoothingFunc(method3)
+
+	// Method4 applies the same geometric sequence as Method3, but further
+	// shrinks the replacement by 1/ln(len(candidate)), giving less
+	// confidence to short candidates.
+	Method4 SmoothingMethod = smoothingFunc(method4)
+
+	// Method5 smooths every order's precision by averaging it with its
+	// immediate neighbors, p_{n-1} and p_{n+1}.
+	Method5 SmoothingMethod = smoothingFunc(method5)
+
+	// Method6 interpolates p_n with p_{n-1}^2/p_{n-2}, weighted by how many
+	// n-grams of order n the candidate has.
+	Method6 SmoothingMethod = smoothingFunc(method6)
+
+	// Method7 applies Method4 followed by Method5.
+	Method7 SmoothingMethod = smoothingFunc(method7)
+)
+
+// rawPrecisions converts the per-order numerator/denominator pairs into
+// plain fractions, treating an empty denominator (the candidate is shorter
+// than that n-gram order) as a precision of 0.
+func rawPrecisions(numerators, denominators []int) []float64 {
+	ps := make([]float64, len(numerators))
+	for i := range numerators {
+		if denominators[i] == 0 {
+			continue
+		}
+		ps[i] = float64(numerators[i]) / float64(denominators[i])
+	}
+	return ps
+}
+
+func method0(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	return rawPrecisions(numerators, denominators)
+}
+
+func method1(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	ps := rawPrecisions(numerators, denominators)
+	for i := range ps {
+		if denominators[i] != 0 && numerators[i] == 0 {
+			ps[i] = smoothingEpsilon / float64(denominators[i])
+		}
+	}
+	return ps
+}
+
+func method2(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	ps := make([]float64, len(numerators))
+	for i := range numerators {
+		if denominators[i] == 0 {
+			continue
+		}
+		ps[i] = (float64(numerators[i]) + 1) / (float64(denominators[i]) + 1)
+	}
+	return ps
+}
+
+func method3(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	ps := rawPrecisions(numerators, denominators)
+	k := 1
+	for i := range ps {
+		if denominators[i] != 0 && numerators[i] == 0 {
+			ps[i] = 1.0 / (math.Pow(2, float64(k)) * float64(denominators[i]))
+			k++
+		}
+	}
+	return ps
+}
+
+func method4(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	ps := rawPrecisions(numerators, denominators)
+	if len(candidate) <= 1 {
+		return ps
+	}
+	logCandidateLen := math.Log(float64(len(candidate)))
+	k := 1
+	for i := range ps {
+		if denominators[i] != 0 && numerators[i] == 0 {
+			ps[i] = 1.0 / (math.Pow(2, float64(k)) * logCandidateLen * float64(denominators[i]))
+			k++
+		}
+	}
+	return ps
+}
+
+func method5(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	return averageNeighbors(candidate, references, rawPrecisions(numerators, denominators), numerators, denominators)
+}
+
+// averageNeighbors implements Method5's smoothing, averaging each p_n with
+// its immediate neighbors p_{n-1} and p_{n+1}. It is shared with Method7,
+// which averages the already Method4-shrunk precisions rather than the raw
+// ones method5 computes for itself.
+func averageNeighbors(candidate Sentence, references []Sentence, precisions []float64, numerators, denominators []int) []float64 {
+	// p[0] is the seeded p_0, p[1:len(precisions)+1] mirror precisions, and
+	// the final entry is p_{n+1} for the highest configured order, computed
+	// by looking one n-gram order beyond the requested weights.
+	p := make([]float64, len(precisions)+2)
+	if len(precisions) > 0 {
+		p[0] = precisions[0] * float64(numerators[0]) / float64(numerators[0]+1)
+	}
+	copy(p[1:], precisions)
+
+	extraNumerator, extraDenominator := modifiedPrecisionCounts(candidate, references, len(precisions)+1)
+	if extraDenominator != 0 {
+		p[len(p)-1] = float64(extraNumerator) / float64(extraDenominator)
+	}
+
+	ps := make([]float64, len(precisions))
+	for i := range ps {
+		ps[i] = (p[i] + p[i+1] + p[i+2]) / 3
+	}
+	return ps
+}
+
+func method6(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	raw := rawPrecisions(numerators, denominators)
+	ps := make([]float64, len(raw))
+	copy(ps, raw)
+
+	for i := 2; i < len(raw); i++ {
+		if raw[i-2] == 0 {
+			continue
+		}
+		pi0 := raw[i-1] * raw[i-1] / raw[i-2]
+		l := float64(denominators[i])
+		ps[i] = (float64(numerators[i]) + smoothingAlpha*pi0) / (l + smoothingAlpha)
+	}
+	return ps
+}
+
+func method7(candidate Sentence, references []Sentence, numerators, denominators []int) []float64 {
+	shrunk := method4(candidate, references, numerators, denominators)
+	return averageNeighbors(candidate, references, shrunk, numerators, denominators)
+}
+
+// ComputeWith calculates the BLEU score for a given candidate, references
+// and ngram weights, using method to smooth away zero-count precisions
+// instead of the fixed add-1 behavior of Smooth. See SmoothingMethod for the
+// available methods.
+func ComputeWith(candidate Sentence, references []Sentence, weights []float64, method SmoothingMethod) float64 {
+	return computeWithPrecisions(lowercased(candidate), lowercasedAll(references), weights, method)
+}
+
+// computeWithPrecisions is ComputeWith without the forced lowercasing, so
+// callers that have already decided whether to fold case -- such as Scorer,
+// which has its own Lowercase option -- don't have it redone for them.
+func computeWithPrecisions(candidate Sentence, references []Sentence, weights []float64, method SmoothingMethod) float64 {
+	numerators := make([]int, len(weights))
+	denominators := make([]int, len(weights))
+	for i := range weights {
+		numerators[i], denominators[i] = modifiedPrecisionCounts(candidate, references, i+1)
+	}
+
+	ps := method.smooth(candidate, references, numerators, denominators)
+
+	s := 0.0
+	overlap := 0
+	for i := range weights {
+		pn := ps[i]
+		if pn > 0.0 {
+			overlap++
+			s += weights[i] * math.Log(pn)
+		}
+	}
+
+	// if none of the ngrams have any overlap with the reference translations,
+	// return 0. See https://github.com/nltk/nltk/issues/1268 for discussion.
+	if overlap == 0 {
+		return 0
+	}
+
+	bp := brevityPenalty(candidate, references)
+	return bp * math.Exp(s)
+}
@@ -0,0 +1,99 @@
+package bleu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeWithMatchesShims(t *testing.T) {
+	candidate := split("artichokes with the butter")
+	references := []Sentence{split("hearts of artichoke in butter sauce")}
+	weights := []float64{0.5, 0.5}
+
+	got := ComputeWith(candidate, references, weights, Method0)
+	want := Compute(split("artichokes with the butter"), references, weights)
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("ComputeWith(Method0) = %v, want %v (same as Compute)", got, want)
+	}
+
+	got = ComputeWith(split("artichokes with the butter"), references, weights, Method2)
+	want = Smooth(split("artichokes with the butter"), references, weights)
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("ComputeWith(Method2) = %v, want %v (same as Smooth)", got, want)
+	}
+}
+
+func TestComputeWithSmoothingMethods(t *testing.T) {
+	candidate := split("Champinones sizzled")
+	references := []Sentence{split("garlic mushrooms")}
+	weights := []float64{0.5, 0.5, 0.5, 0.5}
+
+	// Method0 has no overlapping ngrams at all, so it must still return 0.
+	if score := ComputeWith(candidate, references, weights, Method0); score != 0 {
+		t.Errorf("ComputeWith(Method0) = %v, want 0", score)
+	}
+
+	// Every order but 1 and 2 has no denominator at all here (the candidate
+	// is too short), so methods 1, 3 and 4 only ever smooth those first two
+	// zero-count precisions -- exactly enough arithmetic to catch a wrong
+	// epsilon, a k that starts or increments wrong, or a swapped
+	// denominators[i] index.
+	methods134 := []struct {
+		name   string
+		method SmoothingMethod
+		want   float64
+	}{
+		// p1 = 0.1/2, p2 = 0.1/1, score = sqrt(p1*p2) = sqrt(0.005).
+		{"Method1", Method1, 0.070711},
+		// p1 = 1/(2^1*2), p2 = 1/(2^2*1), both 0.25, score = 0.25.
+		{"Method3", Method3, 0.25},
+		// p1 = p2 = 1/(2^k * ln(2) * denominator), score = that shared value.
+		{"Method4", Method4, 0.360674},
+	}
+	for _, tt := range methods134 {
+		score := ComputeWith(append(Sentence{}, candidate...), references, weights, tt.method)
+		if math.Abs(score-tt.want) > 0.0001 {
+			t.Errorf("ComputeWith(%s) = %v, want %v", tt.name, score, tt.want)
+		}
+	}
+}
+
+// TestComputeWithNeighborAveragingMethods covers methods 5, 6 and 7 against
+// a candidate with a partial, uneven match across orders 1-3 ("ham and egg"
+// vs. "ham and eggs"), since averageNeighbors and method6's interpolation
+// are no-ops on the all-zero precisions TestComputeWithSmoothingMethods'
+// fixture produces -- a wrong neighbor offset or interpolation weight would
+// be invisible there.
+func TestComputeWithNeighborAveragingMethods(t *testing.T) {
+	candidate := split("ham and egg")
+	references := []Sentence{split("ham and eggs")}
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	tests := []struct {
+		name   string
+		method SmoothingMethod
+		want   float64
+	}{
+		{"Method5", Method5, 0.431936},
+		{"Method6", Method6, 0.568110},
+		{"Method7", Method7, 0.344116},
+	}
+	for _, tt := range tests {
+		score := ComputeWith(append(Sentence{}, candidate...), references, weights, tt.method)
+		if math.Abs(score-tt.want) > 0.0001 {
+			t.Errorf("ComputeWith(%s) = %v, want %v", tt.name, score, tt.want)
+		}
+	}
+}
+
+func TestMethod2MatchesAddOneSmoothing(t *testing.T) {
+	candidate := split("ham and egg")
+	references := []Sentence{split("Ham and Eggs")}
+	weights := []float64{0.25, 0.25, 0.25, 0.25}
+
+	got := ComputeWith(candidate, references, weights, Method2)
+	want := 0.7071
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("ComputeWith(Method2) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package bleu
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Tokenizer splits raw text into the tokens Compute and friends score over.
+type Tokenizer interface {
+	Tokenize(text string) Sentence
+}
+
+// WhitespaceTokenizer splits text on runs of whitespace, discarding empty
+// tokens. It is a reasonable default for text that is already roughly
+// tokenized, e.g. separated by single spaces with punctuation attached to
+// its neighboring word.
+type WhitespaceTokenizer struct{}
+
+// Tokenize implements Tokenizer.
+func (WhitespaceTokenizer) Tokenize(text string) Sentence {
+	return strings.Fields(text)
+}
+
+// Passthrough splits text on the literal space character, preserving
+// whatever tokens the caller already produced -- including empty ones from
+// repeated spaces. Use it when candidate and reference strings are already
+// tokenized and joined with a single space, and no further splitting should
+// happen.
+type Passthrough struct{}
+
+// Tokenize implements Tokenizer.
+func (Passthrough) Tokenize(text string) Sentence {
+	return strings.Split(text, " ")
+}
+
+// contractionSuffix matches a word character run immediately followed by a
+// common English contraction suffix, so MosesTokenizer can split "don't"
+// into "do" and "n't" rather than treating the apostrophe as ordinary
+// punctuation.
+var contractionSuffix = regexp.MustCompile(`(?i)(\p{L})(n't|'re|'ve|'ll|'d|'s|'m)\b`)
+
+// punctuation matches the ASCII and common Unicode punctuation marks
+// MosesTokenizer pads with spaces so they become their own tokens. Hyphens
+// and the ASCII apostrophe are deliberately excluded: hyphens are kept as
+// part of compound words (e.g. "twenty-one"), and the ASCII apostrophe is
+// handled by contractionSuffix above. The curly quotes ‘ and ’ are split
+// off like any other listed mark, including when ’ is used as an apostrophe.
+var punctuation = regexp.MustCompile(`([.,!?;:()\[\]{}"“”‘’<>])`)
+
+// MosesTokenizer approximates the tokenization rules of the Moses statistical
+// machine translation toolkit: punctuation is split off into its own
+// tokens, contractions such as "don't" or "it's" are split into their
+// component words, and hyphenated compounds are kept intact.
+type MosesTokenizer struct{}
+
+// Tokenize implements Tokenizer.
+func (MosesTokenizer) Tokenize(text string) Sentence {
+	text = contractionSuffix.ReplaceAllString(text, "$1 $2")
+	text = punctuation.ReplaceAllString(text, " $1 ")
+	return strings.Fields(text)
+}
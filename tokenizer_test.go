@@ -0,0 +1,49 @@
+package bleu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWhitespaceTokenizer(t *testing.T) {
+	got := WhitespaceTokenizer{}.Tokenize("the   cat  sat")
+	want := Sentence{"the", "cat", "sat"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WhitespaceTokenizer.Tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	got := Passthrough{}.Tokenize("the cat sat")
+	want := Sentence{"the", "cat", "sat"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Passthrough.Tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestMosesTokenizer(t *testing.T) {
+	var cases = []struct {
+		text string
+		want Sentence
+	}{
+		{
+			text: "The cat sat on the mat.",
+			want: Sentence{"The", "cat", "sat", "on", "the", "mat", "."},
+		},
+		{
+			text: "I don't think it's working.",
+			want: Sentence{"I", "do", "n't", "think", "it", "'s", "working", "."},
+		},
+		{
+			text: "a well-known twenty-one year old",
+			want: Sentence{"a", "well-known", "twenty-one", "year", "old"},
+		},
+	}
+
+	for _, tt := range cases {
+		got := MosesTokenizer{}.Tokenize(tt.text)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MosesTokenizer.Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}